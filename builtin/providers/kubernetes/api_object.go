@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/kubernetes/pkg/client/restclient"
+
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+// getRawBytes fetches and returns the raw JSON body at path.
+func getRawBytes(client *restclient.RESTClient, path string) ([]byte, error) {
+	return client.Get().AbsPath(path).Do().Raw()
+}
+
+// getRawObject fetches the object at path and decodes it into a generic
+// map, the shape fieldpath.Document and the manifest/live-diff resources
+// work with.
+func getRawObject(client *restclient.RESTClient, path string) (map[string]interface{}, error) {
+	raw, err := getRawBytes(client, path)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("decoding response: %s", err)
+	}
+	return obj, nil
+}
+
+func getManifestObject(client *restclient.RESTClient, apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	return getRawObject(client, resourcePath(apiVersion, kind, namespace, name))
+}
+
+// isNotFoundError reports whether err looks like a Kubernetes 404. The
+// restclient error returned here doesn't carry a typed status in this
+// vendored snapshot, so this falls back to matching on the status text the
+// API server embeds in the error message.
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "not found") || strings.Contains(msg, strconv.Itoa(404))
+}
+
+// flattenManifestObject populates a kubernetes_manifest resource's
+// metadata/manifest fields from the decoded object returned by the API
+// server.
+func flattenManifestObject(d *schema.ResourceData, obj map[string]interface{}) error {
+	metaRaw, _ := obj["metadata"].(map[string]interface{})
+	objectMeta := expandMetadataFromWire(metaRaw)
+	if err := d.Set("metadata", flattenMetadata(objectMeta)); err != nil {
+		return err
+	}
+
+	manifest := make(map[string]interface{}, len(obj))
+	for k, v := range obj {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		default:
+			manifest[k] = v
+		}
+	}
+	return d.Set("manifest", manifest)
+}
+
+// expandMetadataFromWire converts the "metadata" object of a decoded API
+// response (string-keyed JSON, as opposed to the Terraform schema shape
+// expandMetadata reads) into api.ObjectMeta.
+func expandMetadataFromWire(m map[string]interface{}) api.ObjectMeta {
+	meta := api.ObjectMeta{}
+	if v, ok := m["name"].(string); ok {
+		meta.Name = v
+	}
+	if v, ok := m["namespace"].(string); ok {
+		meta.Namespace = v
+	}
+	meta.Labels = stringMapFromWire(m["labels"])
+	meta.Annotations = filterAnnotations(stringMapFromWire(m["annotations"]))
+	return meta
+}
+
+func stringMapFromWire(v interface{}) map[string]string {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, raw := range m {
+		if s, ok := raw.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
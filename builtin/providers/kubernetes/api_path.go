@@ -0,0 +1,60 @@
+package kubernetes
+
+import "strings"
+
+// irregularPlurals covers the common Kinds whose plural form isn't a plain
+// lowercase+"s", so that resourcePath can address the right REST endpoint.
+var irregularPlurals = map[string]string{
+	"Ingress":       "ingresses",
+	"Endpoints":     "endpoints",
+	"NetworkPolicy": "networkpolicies",
+}
+
+// pluralizeKind returns the lowercase plural REST resource name for a Kind.
+func pluralizeKind(kind string) string {
+	if p, ok := irregularPlurals[kind]; ok {
+		return p
+	}
+	lower := strings.ToLower(kind)
+	if strings.HasSuffix(lower, "s") {
+		return lower + "es"
+	}
+	if strings.HasSuffix(lower, "y") {
+		return strings.TrimSuffix(lower, "y") + "ies"
+	}
+	return lower + "s"
+}
+
+// parseAPIVersion splits "group/version" (or "v1" for the legacy core
+// group) into its group and version components.
+func parseAPIVersion(apiVersion string) (group, version string) {
+	parts := strings.SplitN(apiVersion, "/", 2)
+	if len(parts) == 1 {
+		return "", parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// resourcePath builds the REST path for a namespaced or cluster-scoped
+// object, e.g. ("apps/v1", "Deployment", "default", "web") ->
+// "/apis/apps/v1/namespaces/default/deployments/web", or ("v1", "Namespace",
+// "", "default") -> "/api/v1/namespaces/default".
+func resourcePath(apiVersion, kind, namespace, name string) string {
+	group, version := parseAPIVersion(apiVersion)
+	plural := pluralizeKind(kind)
+
+	var base string
+	if group == "" {
+		base = "/api/" + version
+	} else {
+		base = "/apis/" + group + "/" + version
+	}
+	if namespace != "" {
+		base += "/namespaces/" + namespace
+	}
+	base += "/" + plural
+	if name != "" {
+		base += "/" + name
+	}
+	return base
+}
@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/builtin/providers/kubernetes/ordering"
+	"k8s.io/kubernetes/pkg/client/restclient"
+)
+
+// crdEstablishedTimeout bounds how long waitForCRDEstablished polls before failing.
+const crdEstablishedTimeout = 60 * time.Second
+
+// waitForDependencies blocks Create/Update until a CR's CRD reports
+// Established. It is a no-op when `wait_for_dependencies` is disabled, and
+// also for any kind ordering.IsKnownKind recognizes (those have no CRD to wait on).
+// cluster selects which cluster's CRDs to poll, following the same
+// empty-string-means-default convention as clientForCluster.
+func waitForDependencies(enabled bool, cluster, kind string, meta interface{}) error {
+	if !enabled || ordering.IsKnownKind(kind) {
+		return nil
+	}
+	return waitForCRDEstablished(cluster, kind, meta)
+}
+
+// waitForCRDEstablished polls the CRD backing kind until its Established
+// condition is true, retrying on NotFound since a CRD applied moments
+// earlier may not be visible in discovery yet.
+func waitForCRDEstablished(cluster, kind string, meta interface{}) error {
+	clients, ok := meta.(*kubeClientsets)
+	if !ok {
+		return fmt.Errorf("kubernetes: provider meta is not configured for multi-cluster clients")
+	}
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(crdEstablishedTimeout)
+	for {
+		established, err := crdEstablishedForKind(client, kind)
+		if err == nil && established {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("kubernetes: timed out waiting for CRD backing kind %q to become Established: %s", kind, err)
+			}
+			return fmt.Errorf("kubernetes: timed out waiting for CRD backing kind %q to become Established", kind)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// crdList is the subset of a CustomResourceDefinitionList response
+// crdEstablishedForKind needs: each CRD's Kind and Established condition.
+type crdList struct {
+	Items []struct {
+		Spec struct {
+			Names struct {
+				Kind string `json:"kind"`
+			} `json:"names"`
+		} `json:"spec"`
+		Status struct {
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// crdEstablishedForKind looks up the CRD whose spec.names.kind matches kind
+// and reports whether its Established condition is "True". A kind with no
+// matching CRD yet (e.g. not visible in discovery) is reported as not
+// established rather than an error, so the caller's retry loop keeps polling.
+func crdEstablishedForKind(client *restclient.RESTClient, kind string) (bool, error) {
+	raw, err := getRawBytes(client, "/apis/apiextensions.k8s.io/v1/customresourcedefinitions")
+	if err != nil {
+		return false, err
+	}
+
+	var list crdList
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return false, fmt.Errorf("decoding CustomResourceDefinitionList: %s", err)
+	}
+
+	for _, item := range list.Items {
+		if item.Spec.Names.Kind != kind {
+			continue
+		}
+		for _, cond := range item.Status.Conditions {
+			if cond.Type == "Established" {
+				return cond.Status == "True", nil
+			}
+		}
+		return false, nil
+	}
+	return false, nil
+}
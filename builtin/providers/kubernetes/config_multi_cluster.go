@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"k8s.io/kubernetes/pkg/client/restclient"
+	"k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
+)
+
+// clusterConfig holds the connection details for one `cluster` block.
+type clusterConfig struct {
+	Name                 string
+	KubeConfigPath       string
+	KubeConfigContext    string
+	Host                 string
+	Token                string
+	ClientCertificate    string
+	ClientKey            string
+	ClusterCACertificate string
+	Insecure             bool
+}
+
+// expandClusterConfigs converts `cluster` blocks into clusterConfig values.
+func expandClusterConfigs(in []interface{}) []clusterConfig {
+	configs := make([]clusterConfig, 0, len(in))
+	for _, raw := range in {
+		m := raw.(map[string]interface{})
+		configs = append(configs, clusterConfig{
+			Name:                 m["name"].(string),
+			KubeConfigPath:       m["config_path"].(string),
+			KubeConfigContext:    m["config_context"].(string),
+			Host:                 m["host"].(string),
+			Token:                m["token"].(string),
+			ClientCertificate:    m["client_certificate"].(string),
+			ClientKey:            m["client_key"].(string),
+			ClusterCACertificate: m["cluster_ca_certificate"].(string),
+			Insecure:             m["insecure"].(bool),
+		})
+	}
+	return configs
+}
+
+// kubeClientsets holds one client per named cluster plus the default cluster name.
+type kubeClientsets struct {
+	defaultCluster      string
+	clients             map[string]*restclient.RESTClient
+	hosts               map[string]string
+	waitForDependencies bool
+}
+
+// newKubeClientsets builds a client per configured cluster; configs[0] is the default.
+func newKubeClientsets(configs []clusterConfig, waitForDependencies bool) (*kubeClientsets, error) {
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("kubernetes: at least one `cluster` block must be configured")
+	}
+
+	k := &kubeClientsets{
+		defaultCluster:      configs[0].Name,
+		clients:             make(map[string]*restclient.RESTClient, len(configs)),
+		hosts:               make(map[string]string, len(configs)),
+		waitForDependencies: waitForDependencies,
+	}
+	for _, cfg := range configs {
+		restCfg, err := restConfigForCluster(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: configuring cluster %q: %s", cfg.Name, err)
+		}
+		client, err := restclient.RESTClientFor(restCfg)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: configuring cluster %q: %s", cfg.Name, err)
+		}
+		k.clients[cfg.Name] = client
+		k.hosts[cfg.Name] = restCfg.Host
+	}
+	return k, nil
+}
+
+// restConfigForCluster builds the REST config for one `cluster` block. When
+// `config_path` is set, it's loaded as a kubeconfig first (selecting
+// `config_context`, or the kubeconfig's current-context if unset); any of
+// host/token/TLS fields set directly on the block are then layered on top as
+// overrides, so a block can mix "load from kubeconfig" with one-off
+// overrides like `insecure`.
+func restConfigForCluster(cfg clusterConfig) (*restclient.Config, error) {
+	if cfg.KubeConfigPath == "" {
+		return &restclient.Config{
+			Host:        cfg.Host,
+			BearerToken: cfg.Token,
+			TLSClientConfig: restclient.TLSClientConfig{
+				CertData: []byte(cfg.ClientCertificate),
+				KeyData:  []byte(cfg.ClientKey),
+				CAData:   []byte(cfg.ClusterCACertificate),
+				Insecure: cfg.Insecure,
+			},
+		}, nil
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = cfg.KubeConfigPath
+	overrides := &clientcmd.ConfigOverrides{}
+	if cfg.KubeConfigContext != "" {
+		overrides.CurrentContext = cfg.KubeConfigContext
+	}
+
+	restCfg, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading kubeconfig %q: %s", cfg.KubeConfigPath, err)
+	}
+
+	if cfg.Host != "" {
+		restCfg.Host = cfg.Host
+	}
+	if cfg.Token != "" {
+		restCfg.BearerToken = cfg.Token
+	}
+	if cfg.ClientCertificate != "" {
+		restCfg.TLSClientConfig.CertData = []byte(cfg.ClientCertificate)
+	}
+	if cfg.ClientKey != "" {
+		restCfg.TLSClientConfig.KeyData = []byte(cfg.ClientKey)
+	}
+	if cfg.ClusterCACertificate != "" {
+		restCfg.TLSClientConfig.CAData = []byte(cfg.ClusterCACertificate)
+	}
+	if cfg.Insecure {
+		restCfg.TLSClientConfig.Insecure = true
+	}
+	return restCfg, nil
+}
+
+// clientForCluster looks up the client for name, falling back to the default cluster.
+func (k *kubeClientsets) clientForCluster(name string) (*restclient.RESTClient, error) {
+	if name == "" {
+		name = k.defaultCluster
+	}
+	client, ok := k.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("kubernetes: no cluster configuration named %q", name)
+	}
+	return client, nil
+}
+
+// hostForCluster looks up the API server host for name, following the same fallback as clientForCluster.
+func (k *kubeClientsets) hostForCluster(name string) (string, error) {
+	if name == "" {
+		name = k.defaultCluster
+	}
+	host, ok := k.hosts[name]
+	if !ok {
+		return "", fmt.Errorf("kubernetes: no cluster configuration named %q", name)
+	}
+	return host, nil
+}
@@ -0,0 +1,235 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/builtin/providers/kubernetes/fieldpath"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// diffSeverity classifies a live-diff operation so that CI pipelines can
+// gate on real drift while ignoring server-managed noise.
+type diffSeverity string
+
+const (
+	// diffSeveritySemantic means the field is meaningfully different from
+	// what Terraform expects to have applied, e.g. a changed replica count.
+	diffSeveritySemantic diffSeverity = "semantic"
+	// diffSeverityCosmetic means the field differs only because the server
+	// populated a default, timestamp, or other value Terraform doesn't
+	// manage.
+	diffSeverityCosmetic diffSeverity = "cosmetic"
+)
+
+// cosmeticFields are the JSON-Pointer paths of top-level metadata fields the
+// API server manages on its own; a diff limited to these is never semantic
+// drift.
+var cosmeticFields = map[string]bool{
+	"/metadata/resourceVersion": true,
+	"/metadata/selfLink":        true,
+	"/metadata/uid":             true,
+	"/metadata/generation":      true,
+}
+
+// dataSourceKubernetesLiveDiff returns a data source that fetches the
+// current server state for a set of resources and returns a structured,
+// JSON-Patch-formatted diff of their labels/annotations against Terraform's
+// desired state, without requiring a full `terraform plan`. It only diffs
+// `metadata.labels`/`metadata.annotations` — the `resource` block doesn't
+// carry a full desired manifest to compare Kind-specific spec fields
+// against, so drift in those fields isn't detected here.
+func dataSourceKubernetesLiveDiff() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesLiveDiffRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster":     {Type: schema.TypeString, Optional: true},
+						"api_version": {Type: schema.TypeString, Required: true},
+						"kind":        {Type: schema.TypeString, Required: true},
+						"namespace":   {Type: schema.TypeString, Optional: true},
+						"name":        {Type: schema.TypeString, Required: true},
+						"labels": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The labels Terraform expects this resource to have.",
+						},
+						"annotations": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The annotations Terraform expects this resource to have.",
+						},
+					},
+				},
+			},
+			"diffs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_id": {Type: schema.TypeString, Computed: true},
+						"operations":  {Type: schema.TypeString, Computed: true},
+						"severity":    {Type: schema.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// liveDiffResult is one entry of the data source's `diffs` output: the
+// JSON-Patch operations needed to reconcile desired state with what's
+// currently on the server. Its severity is classified later, in
+// flattenLiveDiffResults.
+type liveDiffResult struct {
+	ResourceID string
+	Operations []fieldpath.Operation
+}
+
+// desiredResource is one entry of the `resource` input block: what
+// Terraform expects a live object's labels/annotations to look like.
+type desiredResource struct {
+	Cluster     string
+	APIVersion  string
+	Kind        string
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func expandDesiredResource(m map[string]interface{}) desiredResource {
+	return desiredResource{
+		Cluster:     m["cluster"].(string),
+		APIVersion:  m["api_version"].(string),
+		Kind:        m["kind"].(string),
+		Namespace:   m["namespace"].(string),
+		Name:        m["name"].(string),
+		Labels:      stringMapFromWire(m["labels"]),
+		Annotations: filterAnnotations(stringMapFromWire(m["annotations"])),
+	}
+}
+
+func dataSourceKubernetesLiveDiffRead(d *schema.ResourceData, meta interface{}) error {
+	clients, ok := meta.(*kubeClientsets)
+	if !ok {
+		return fmt.Errorf("kubernetes_live_diff: provider meta is not configured for multi-cluster clients")
+	}
+
+	raw := d.Get("resource").([]interface{})
+	results := make([]liveDiffResult, 0, len(raw))
+	for _, r := range raw {
+		desired := expandDesiredResource(r.(map[string]interface{}))
+		result, err := liveDiffForResource(clients, desired)
+		if err != nil {
+			return fmt.Errorf("kubernetes_live_diff: diffing %s/%s: %s", desired.Namespace, desired.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	flattened, err := flattenLiveDiffResults(results)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d", len(raw)))
+	return d.Set("diffs", flattened)
+}
+
+// liveDiffForResource fetches the current server object for desired and
+// builds the JSON-Patch operations needed to bring its labels/annotations in
+// line with what Terraform expects.
+func liveDiffForResource(clients *kubeClientsets, desired desiredResource) (liveDiffResult, error) {
+	client, err := clients.clientForCluster(desired.Cluster)
+	if err != nil {
+		return liveDiffResult{}, err
+	}
+
+	obj, err := getManifestObject(client, desired.APIVersion, desired.Kind, desired.Namespace, desired.Name)
+	if err != nil {
+		return liveDiffResult{}, err
+	}
+	metaRaw, _ := obj["metadata"].(map[string]interface{})
+	live := expandMetadataFromWire(metaRaw)
+
+	builder := fieldpath.NewPatchBuilder()
+	builder.AddOrReplaceStringMap(live.Labels, desired.Labels, "metadata", "labels")
+	builder.AddOrReplaceStringMap(live.Annotations, desired.Annotations, "metadata", "annotations")
+
+	return liveDiffResult{
+		ResourceID: buildId(desired.Cluster, live),
+		Operations: builder.Operations(),
+	}, nil
+}
+
+// suppressServerManagedAnnotations drops PATCH operations against
+// annotation keys the server manages itself (kubectl.kubernetes.io/*,
+// *.kubernetes.io/*, etc.), reusing the same isInternalAnnotationKey check
+// filterAnnotations applies to the typed resources so a live diff doesn't
+// flag churn nothing in Terraform configuration controls.
+func suppressServerManagedAnnotations(ops []fieldpath.Operation) []fieldpath.Operation {
+	out := make([]fieldpath.Operation, 0, len(ops))
+	for _, op := range ops {
+		key := strings.Replace(strings.Replace(lastPathSegment(op.Path), "~1", "/", -1), "~0", "~", -1)
+		if isAnnotationPath(op.Path) && isInternalAnnotationKey(key) {
+			continue
+		}
+		out = append(out, op)
+	}
+	return out
+}
+
+func isAnnotationPath(path string) bool {
+	return len(path) > len("/metadata/annotations/") && path[:len("/metadata/annotations/")] == "/metadata/annotations/"
+}
+
+// classifySeverity inspects the field paths touched by ops and reports
+// diffSeverityCosmetic if every one of them is a server-managed metadata
+// field the server populates on its own, diffSeveritySemantic otherwise.
+func classifySeverity(ops []fieldpath.Operation) diffSeverity {
+	for _, op := range ops {
+		if !cosmeticFields[op.Path] {
+			return diffSeveritySemantic
+		}
+	}
+	return diffSeverityCosmetic
+}
+
+func lastPathSegment(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' {
+			return path[i+1:]
+		}
+	}
+	return path
+}
+
+// flattenLiveDiffResults turns liveDiffResult values into the map shape the
+// `diffs` schema expects, suppressing server-managed annotation keys the
+// same way filterAnnotations does for the typed resources. `operations` is
+// real JSON (via json.Marshal), not a Go-syntax dump, so it's consumable
+// from Terraform config with jsondecode().
+func flattenLiveDiffResults(results []liveDiffResult) ([]map[string]interface{}, error) {
+	out := make([]map[string]interface{}, 0, len(results))
+	for _, r := range results {
+		ops := suppressServerManagedAnnotations(r.Operations)
+		opsJSON, err := json.Marshal(ops)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes_live_diff: encoding JSON-Patch operations for %q: %s", r.ResourceID, err)
+		}
+		out = append(out, map[string]interface{}{
+			"resource_id": r.ResourceID,
+			"operations":  string(opsJSON),
+			"severity":    string(classifySeverity(ops)),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,142 @@
+// Package fieldpath provides typed, path-based accessors over arbitrary
+// Kubernetes object maps (ObjectMeta, spec trees, etc.) along with a
+// PatchBuilder that accumulates RFC 6902 JSON-Patch operations. It exists so
+// that the various `kubernetes_*` resources in this provider can share a
+// single, well-tested implementation of "read this field" / "patch this
+// field" instead of each hand-rolling flatten/expand and PatchOperations
+// code.
+package fieldpath
+
+import "fmt"
+
+// Document wraps an arbitrary decoded JSON/YAML value (as produced by
+// encoding/json or yaml.Unmarshal into map[string]interface{}) and exposes
+// typed accessors over dotted field paths, e.g. "metadata.labels.app".
+type Document interface {
+	// GetString returns the string value at path, or an error if the path
+	// doesn't exist or isn't a string.
+	GetString(path string) (string, error)
+	// GetStringMap returns the map[string]string value at path.
+	GetStringMap(path string) (map[string]string, error)
+	// GetSlice returns the []interface{} value at path.
+	GetSlice(path string) ([]interface{}, error)
+	// GetInt64 returns the int64 value at path.
+	GetInt64(path string) (int64, error)
+	// Set writes value at path, creating intermediate maps as needed.
+	Set(path string, value interface{}) error
+}
+
+// document is the default Document implementation, backed by a plain
+// map[string]interface{} tree such as the one produced by decoding an
+// api.ObjectMeta or a CRD spec into generic JSON.
+type document struct {
+	root map[string]interface{}
+}
+
+// NewDocument wraps root for typed field-path access. root is held by
+// reference; Set mutates it in place.
+func NewDocument(root map[string]interface{}) Document {
+	return &document{root: root}
+}
+
+func (d *document) GetString(path string) (string, error) {
+	v, err := get(d.root, splitPath(path))
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("fieldpath: value at %q is a %T, not a string", path, v)
+	}
+	return s, nil
+}
+
+func (d *document) GetStringMap(path string) (map[string]string, error) {
+	v, err := get(d.root, splitPath(path))
+	if err != nil {
+		return nil, err
+	}
+	switch m := v.(type) {
+	case map[string]string:
+		return m, nil
+	case map[string]interface{}:
+		out := make(map[string]string, len(m))
+		for k, raw := range m {
+			s, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("fieldpath: value at %q[%q] is a %T, not a string", path, k, raw)
+			}
+			out[k] = s
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("fieldpath: value at %q is a %T, not a map", path, v)
+	}
+}
+
+func (d *document) GetSlice(path string) ([]interface{}, error) {
+	v, err := get(d.root, splitPath(path))
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("fieldpath: value at %q is a %T, not a slice", path, v)
+	}
+	return s, nil
+}
+
+func (d *document) GetInt64(path string) (int64, error) {
+	v, err := get(d.root, splitPath(path))
+	if err != nil {
+		return 0, err
+	}
+	switch n := v.(type) {
+	case int64:
+		return n, nil
+	case int:
+		return int64(n), nil
+	case float64:
+		return int64(n), nil
+	default:
+		return 0, fmt.Errorf("fieldpath: value at %q is a %T, not a number", path, v)
+	}
+}
+
+func (d *document) Set(path string, value interface{}) error {
+	return set(d.root, splitPath(path), value)
+}
+
+func get(root map[string]interface{}, segments []string) (interface{}, error) {
+	cur := interface{}(root)
+	for i, seg := range segments {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("fieldpath: %q is not a map at segment %q", joinPath(segments[:i]), seg)
+		}
+		v, ok := m[seg]
+		if !ok {
+			return nil, fmt.Errorf("fieldpath: no value at %q", joinPath(segments[:i+1]))
+		}
+		cur = v
+	}
+	return cur, nil
+}
+
+func set(root map[string]interface{}, segments []string, value interface{}) error {
+	cur := root
+	for i, seg := range segments[:len(segments)-1] {
+		next, ok := cur[seg]
+		if !ok {
+			next = map[string]interface{}{}
+			cur[seg] = next
+		}
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("fieldpath: %q is not a map", joinPath(segments[:i+1]))
+		}
+		cur = m
+	}
+	cur[segments[len(segments)-1]] = value
+	return nil
+}
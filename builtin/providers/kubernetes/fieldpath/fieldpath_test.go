@@ -0,0 +1,127 @@
+package fieldpath
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDocumentGetString(t *testing.T) {
+	doc := NewDocument(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "web",
+		},
+	})
+	got, err := doc.GetString("metadata.name")
+	if err != nil {
+		t.Fatalf("GetString returned error: %s", err)
+	}
+	if got != "web" {
+		t.Errorf("GetString = %q, want %q", got, "web")
+	}
+
+	if _, err := doc.GetString("metadata.missing"); err == nil {
+		t.Error("GetString on a missing path should error")
+	}
+}
+
+func TestDocumentSet(t *testing.T) {
+	doc := NewDocument(map[string]interface{}{})
+	if err := doc.Set("metadata.labels.app", "web"); err != nil {
+		t.Fatalf("Set returned error: %s", err)
+	}
+	got, err := doc.GetString("metadata.labels.app")
+	if err != nil {
+		t.Fatalf("GetString returned error: %s", err)
+	}
+	if got != "web" {
+		t.Errorf("GetString = %q, want %q", got, "web")
+	}
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"app", "app"},
+		{"kubernetes.io/managed-by", "kubernetes.io~1managed-by"},
+		{"a~b", "a~0b"},
+		{"a~b/c", "a~0b~1c"},
+	}
+	for _, c := range cases {
+		if got := escapeJSONPointer(c.in); got != c.want {
+			t.Errorf("escapeJSONPointer(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestToJSONPointer(t *testing.T) {
+	got := toJSONPointer("metadata", "annotations", "kubernetes.io/managed-by")
+	want := "/metadata/annotations/kubernetes.io~1managed-by"
+	if got != want {
+		t.Errorf("toJSONPointer = %q, want %q", got, want)
+	}
+}
+
+func TestPatchBuilderAddReplaceRemove(t *testing.T) {
+	ops := NewPatchBuilder().
+		Add("web", "metadata", "labels", "app").
+		Replace(3, "spec", "replicas").
+		Remove("metadata", "annotations", "stale").
+		Operations()
+
+	want := []Operation{
+		{Op: "add", Path: "/metadata/labels/app", Value: "web"},
+		{Op: "replace", Path: "/spec/replicas", Value: 3},
+		{Op: "remove", Path: "/metadata/annotations/stale"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("Operations() = %#v, want %#v", ops, want)
+	}
+}
+
+func TestAddOrReplaceStringMap(t *testing.T) {
+	old := map[string]string{"a": "1", "b": "2"}
+	new := map[string]string{"a": "1", "b": "3", "c": "4"}
+
+	ops := NewPatchBuilder().AddOrReplaceStringMap(old, new, "metadata", "labels").Operations()
+
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Path < ops[j].Path })
+
+	want := []Operation{
+		{Op: "add", Path: "/metadata/labels/b", Value: "3"},
+		{Op: "add", Path: "/metadata/labels/c", Value: "4"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("AddOrReplaceStringMap() = %#v, want %#v", ops, want)
+	}
+}
+
+func TestAddOrReplaceStringMapRemovesMissingKeys(t *testing.T) {
+	old := map[string]string{"a": "1", "b": "2"}
+	new := map[string]string{"a": "1"}
+
+	ops := NewPatchBuilder().AddOrReplaceStringMap(old, new, "metadata", "labels").Operations()
+
+	want := []Operation{
+		{Op: "remove", Path: "/metadata/labels/b"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("AddOrReplaceStringMap() = %#v, want %#v", ops, want)
+	}
+}
+
+func TestAddOrReplaceStringMapEscapesKeys(t *testing.T) {
+	old := map[string]string{}
+	new := map[string]string{"kubernetes.io/managed-by": "terraform"}
+
+	ops := NewPatchBuilder().AddOrReplaceStringMap(old, new, "metadata", "annotations").Operations()
+
+	want := []Operation{
+		{Op: "add", Path: "/metadata/annotations/kubernetes.io~1managed-by", Value: "terraform"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("AddOrReplaceStringMap() = %#v, want %#v", ops, want)
+	}
+}
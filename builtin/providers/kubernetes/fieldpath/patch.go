@@ -0,0 +1,66 @@
+package fieldpath
+
+// Operation is a single RFC 6902 JSON-Patch operation.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// PatchBuilder accumulates JSON-Patch operations keyed by JSON pointer path,
+// taking care of add-vs-replace semantics and annotation/label key escaping
+// so that resources don't need to hand-roll PatchOperations slices.
+type PatchBuilder struct {
+	ops []Operation
+}
+
+// NewPatchBuilder returns an empty PatchBuilder.
+func NewPatchBuilder() *PatchBuilder {
+	return &PatchBuilder{}
+}
+
+// Replace appends a "replace" operation for an existing field. pathSegments
+// are escaped individually before being joined into the pointer.
+func (b *PatchBuilder) Replace(value interface{}, pathSegments ...string) *PatchBuilder {
+	b.ops = append(b.ops, Operation{Op: "replace", Path: toJSONPointer(pathSegments...), Value: value})
+	return b
+}
+
+// Add appends an "add" operation for a field that may not exist yet -
+// Kubernetes treats "add" on an existing key as an upsert, so this is also
+// what's used for map entries whose prior presence is unknown.
+func (b *PatchBuilder) Add(value interface{}, pathSegments ...string) *PatchBuilder {
+	b.ops = append(b.ops, Operation{Op: "add", Path: toJSONPointer(pathSegments...), Value: value})
+	return b
+}
+
+// Remove appends a "remove" operation.
+func (b *PatchBuilder) Remove(pathSegments ...string) *PatchBuilder {
+	b.ops = append(b.ops, Operation{Op: "remove", Path: toJSONPointer(pathSegments...)})
+	return b
+}
+
+// AddOrReplaceStringMap diffs old and new against each other and appends the
+// operations needed to bring old up to new at the given map path: "add" for
+// new/changed keys, "remove" for keys present in old but absent from new.
+// This is the PatchBuilder equivalent of the old diffStringMap helper, with
+// correct "~"/"/" escaping for annotation and label keys.
+func (b *PatchBuilder) AddOrReplaceStringMap(old, new map[string]string, mapPathSegments ...string) *PatchBuilder {
+	for k, v := range new {
+		if oldV, ok := old[k]; !ok || oldV != v {
+			b.Add(v, append(append([]string{}, mapPathSegments...), k)...)
+		}
+	}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			b.Remove(append(append([]string{}, mapPathSegments...), k)...)
+		}
+	}
+	return b
+}
+
+// Operations returns the accumulated operations in the order they were
+// added.
+func (b *PatchBuilder) Operations() []Operation {
+	return b.ops
+}
@@ -0,0 +1,36 @@
+package fieldpath
+
+import "strings"
+
+// splitPath turns a dotted field path ("metadata.labels.app") into its
+// segments. It does not perform JSON-Pointer escaping; use escapeJSONPointer
+// when turning segments into an RFC 6902 "path".
+func splitPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+func joinPath(segments []string) string {
+	return strings.Join(segments, ".")
+}
+
+// escapeJSONPointer escapes "~" and "/" in a single JSON-Pointer reference
+// token per RFC 6901 section 3. Kubernetes annotation and label keys
+// routinely contain "/" (e.g. "kubernetes.io/managed-by"), so every segment
+// must be escaped before being joined into a PATCH "path" - the historical
+// diffStringMap helper this package replaces did not do this.
+func escapeJSONPointer(segment string) string {
+	segment = strings.Replace(segment, "~", "~0", -1)
+	segment = strings.Replace(segment, "/", "~1", -1)
+	return segment
+}
+
+// toJSONPointer escapes each segment with escapeJSONPointer and joins them
+// into an RFC 6901 JSON Pointer, e.g. ["metadata", "labels", "a/b"] ->
+// "/metadata/labels/a~1b".
+func toJSONPointer(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, s := range segments {
+		escaped[i] = escapeJSONPointer(s)
+	}
+	return "/" + strings.Join(escaped, "/")
+}
@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// openAPISchemaDoc is the subset of the Kubernetes OpenAPI v2 discovery
+// document (served at /openapi/v2) that the kubernetes_manifest resource
+// needs: enough to know which top-level fields a given Kind requires and
+// whether the server even knows about the Kind yet (relevant for CRDs whose
+// schema shows up only after the CRD is Established).
+type openAPISchemaDoc struct {
+	Definitions map[string]openAPIDefinition `json:"definitions"`
+}
+
+type openAPIDefinition struct {
+	Required          []string                     `json:"required"`
+	Properties        map[string]openAPIDefinition `json:"properties"`
+	GroupVersionKinds []openAPIGroupVersionKind     `json:"x-kubernetes-group-version-kind"`
+}
+
+// openAPIGroupVersionKind is one entry of a definition's
+// "x-kubernetes-group-version-kind" extension: the actual, authoritative way
+// to find a Kind's schema. The "definitions" map key itself follows Go
+// import-path-derived conventions (e.g. "io.k8s.api.apps.v1.Deployment" for
+// built-ins, "<group>.<version>.<kind>" for CRDs) that can't be
+// reconstructed from an apiVersion/kind pair alone.
+type openAPIGroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// openAPISchemaCache fetches a cluster's OpenAPI schema on first use and
+// keeps it until Refresh. applyKubernetesManifest builds a new cache for each
+// object it applies, so the schema is fetched once per apply call and is not
+// shared between resources or across applies. It is safe for concurrent use.
+type openAPISchemaCache struct {
+	mu     sync.Mutex
+	host   string
+	client *http.Client
+	doc    *openAPISchemaDoc
+}
+
+func newOpenAPISchemaCache(host string, client *http.Client) *openAPISchemaCache {
+	return &openAPISchemaCache{host: host, client: client}
+}
+
+// Schema returns the cached OpenAPI document, fetching it from the cluster
+// on first use.
+func (c *openAPISchemaCache) Schema() (*openAPISchemaDoc, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.doc != nil {
+		return c.doc, nil
+	}
+	return c.refreshLocked()
+}
+
+// Refresh re-fetches the OpenAPI document, discarding any cached copy. Used
+// when a CRD has just been applied and its schema isn't in the cached
+// document yet.
+func (c *openAPISchemaCache) Refresh() (*openAPISchemaDoc, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshLocked()
+}
+
+func (c *openAPISchemaCache) refreshLocked() (*openAPISchemaDoc, error) {
+	resp, err := c.client.Get(c.host + "/openapi/v2")
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes_manifest: fetching OpenAPI schema: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes_manifest: reading OpenAPI schema: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kubernetes_manifest: fetching OpenAPI schema: unexpected status %s", resp.Status)
+	}
+
+	doc := &openAPISchemaDoc{}
+	if err := json.Unmarshal(body, doc); err != nil {
+		return nil, fmt.Errorf("kubernetes_manifest: decoding OpenAPI schema: %s", err)
+	}
+	c.doc = doc
+	return doc, nil
+}
+
+// definitionForGVK returns the OpenAPI definition whose
+// "x-kubernetes-group-version-kind" extension matches group/version/kind.
+// refresh controls whether a cache miss triggers a schema refetch, which is
+// how CRDs that were just applied become visible without requiring a full
+// provider restart.
+func (c *openAPISchemaCache) definitionForGVK(group, version, kind string, refresh bool) (*openAPIDefinition, error) {
+	doc, err := c.Schema()
+	if err != nil {
+		return nil, err
+	}
+	if def, ok := doc.findDefinition(group, version, kind); ok {
+		return def, nil
+	}
+	if !refresh {
+		return nil, fmt.Errorf("kubernetes_manifest: no OpenAPI schema found for %s/%s %s", group, version, kind)
+	}
+	doc, err = c.Refresh()
+	if err != nil {
+		return nil, err
+	}
+	if def, ok := doc.findDefinition(group, version, kind); ok {
+		return def, nil
+	}
+	return nil, fmt.Errorf("kubernetes_manifest: no OpenAPI schema found for %s/%s %s after refresh", group, version, kind)
+}
+
+// findDefinition scans doc's definitions for one whose
+// x-kubernetes-group-version-kind extension matches group/version/kind.
+func (doc *openAPISchemaDoc) findDefinition(group, version, kind string) (*openAPIDefinition, bool) {
+	for name, def := range doc.Definitions {
+		for _, gvk := range def.GroupVersionKinds {
+			if gvk.Group == group && gvk.Version == version && gvk.Kind == kind {
+				found := doc.Definitions[name]
+				return &found, true
+			}
+		}
+	}
+	return nil, false
+}
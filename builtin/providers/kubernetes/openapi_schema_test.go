@@ -0,0 +1,77 @@
+package kubernetes
+
+import "testing"
+
+// sampleDiscoveryDoc mirrors the shape of a real /openapi/v2 response: the
+// definitions map key is the Go-import-path-derived name, and the Kind is
+// only discoverable via the x-kubernetes-group-version-kind extension.
+var sampleDiscoveryDoc = &openAPISchemaDoc{
+	Definitions: map[string]openAPIDefinition{
+		"io.k8s.api.apps.v1.Deployment": {
+			Required: []string{"spec"},
+			GroupVersionKinds: []openAPIGroupVersionKind{
+				{Group: "apps", Version: "v1", Kind: "Deployment"},
+			},
+		},
+		"io.k8s.api.core.v1.Namespace": {
+			Required: []string{},
+			GroupVersionKinds: []openAPIGroupVersionKind{
+				{Group: "", Version: "v1", Kind: "Namespace"},
+			},
+		},
+		"com.example.stable.v1.Widget": {
+			Required: []string{"spec"},
+			GroupVersionKinds: []openAPIGroupVersionKind{
+				{Group: "example.com", Version: "v1", Kind: "Widget"},
+			},
+		},
+	},
+}
+
+func TestFindDefinitionMatchesByGroupVersionKind(t *testing.T) {
+	def, ok := sampleDiscoveryDoc.findDefinition("apps", "v1", "Deployment")
+	if !ok {
+		t.Fatal("expected to find a definition for apps/v1 Deployment")
+	}
+	if len(def.Required) != 1 || def.Required[0] != "spec" {
+		t.Errorf("Required = %v, want [\"spec\"]", def.Required)
+	}
+}
+
+func TestFindDefinitionCoreGroupIsEmptyString(t *testing.T) {
+	if _, ok := sampleDiscoveryDoc.findDefinition("", "v1", "Namespace"); !ok {
+		t.Error("expected to find a definition for the core v1 Namespace")
+	}
+}
+
+func TestFindDefinitionMatchesCRDs(t *testing.T) {
+	if _, ok := sampleDiscoveryDoc.findDefinition("example.com", "v1", "Widget"); !ok {
+		t.Error("expected to find a definition for example.com/v1 Widget")
+	}
+}
+
+func TestFindDefinitionNoMatch(t *testing.T) {
+	if _, ok := sampleDiscoveryDoc.findDefinition("apps", "v1", "StatefulSet"); ok {
+		t.Error("did not expect to find a definition for apps/v1 StatefulSet")
+	}
+}
+
+func TestDefinitionForGVKUsesCacheWithoutRefetching(t *testing.T) {
+	cache := &openAPISchemaCache{doc: sampleDiscoveryDoc}
+
+	def, err := cache.definitionForGVK("apps", "v1", "Deployment", false)
+	if err != nil {
+		t.Fatalf("definitionForGVK returned error: %s", err)
+	}
+	if len(def.Required) != 1 || def.Required[0] != "spec" {
+		t.Errorf("Required = %v, want [\"spec\"]", def.Required)
+	}
+}
+
+func TestDefinitionForGVKNoMatchNoRefreshErrors(t *testing.T) {
+	cache := &openAPISchemaCache{doc: sampleDiscoveryDoc}
+
+	if _, err := cache.definitionForGVK("apps", "v1", "StatefulSet", false); err == nil {
+		t.Error("expected an error when refresh is disabled and no definition matches")
+	}
+}
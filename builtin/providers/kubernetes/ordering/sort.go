@@ -0,0 +1,67 @@
+// Package ordering sorts Kubernetes resources into apply order: namespaces
+// and CRDs first, then RBAC, then ConfigMaps/Secrets, then workloads.
+package ordering
+
+import (
+	"sort"
+
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+// Resource is the minimal information ordering needs about a planned resource.
+type Resource struct {
+	Kind string
+	Meta api.ObjectMeta
+}
+
+// tier assigns a coarse apply-order bucket to a Kind. Lower tiers apply
+// first; kinds absent from this map (including all CRs) fall to defaultTier.
+var tier = map[string]int{
+	"Namespace":                0,
+	"CustomResourceDefinition": 0,
+	"ClusterRole":              1,
+	"ClusterRoleBinding":       1,
+	"Role":                     1,
+	"RoleBinding":              1,
+	"ServiceAccount":           1,
+	"ConfigMap":                2,
+	"Secret":                   2,
+	"PersistentVolume":         2,
+	"PersistentVolumeClaim":    2,
+	"Service":                  3,
+	"Deployment":               4,
+	"StatefulSet":              4,
+	"DaemonSet":                4,
+	"Job":                      4,
+	"CronJob":                  4,
+	"Pod":                      4,
+}
+
+const defaultTier = 4
+
+// IsKnownKind reports whether kind has an explicit tier, as opposed to
+// falling back to defaultTier. Unknown kinds are treated as possible custom
+// resources whose CRD may still be propagating.
+func IsKnownKind(kind string) bool {
+	_, ok := tier[kind]
+	return ok
+}
+
+// SortKey returns the apply-order bucket for a resource of the given kind.
+// Lower values apply first; a shared SortKey means no ordering relationship.
+func SortKey(meta api.ObjectMeta, kind string) int {
+	if t, ok := tier[kind]; ok {
+		return t
+	}
+	return defaultTier
+}
+
+// Sort orders resources by SortKey, stable within a tier.
+func Sort(resources []Resource) []Resource {
+	sorted := make([]Resource, len(resources))
+	copy(sorted, resources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return SortKey(sorted[i].Meta, sorted[i].Kind) < SortKey(sorted[j].Meta, sorted[j].Kind)
+	})
+	return sorted
+}
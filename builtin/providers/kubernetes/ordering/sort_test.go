@@ -0,0 +1,70 @@
+package ordering
+
+import (
+	"testing"
+
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+func TestSortKeyKnownKinds(t *testing.T) {
+	cases := []struct {
+		kind string
+		want int
+	}{
+		{"Namespace", 0},
+		{"CustomResourceDefinition", 0},
+		{"ClusterRole", 1},
+		{"ConfigMap", 2},
+		{"Service", 3},
+		{"Deployment", 4},
+	}
+	for _, c := range cases {
+		if got := SortKey(api.ObjectMeta{}, c.kind); got != c.want {
+			t.Errorf("SortKey(%q) = %d, want %d", c.kind, got, c.want)
+		}
+	}
+}
+
+func TestSortKeyUnknownKindDefaultsToWorkloadTier(t *testing.T) {
+	if got := SortKey(api.ObjectMeta{}, "MyCustomResource"); got != defaultTier {
+		t.Errorf("SortKey(unknown) = %d, want %d", got, defaultTier)
+	}
+}
+
+func TestIsKnownKind(t *testing.T) {
+	if !IsKnownKind("Namespace") {
+		t.Error("Namespace should be a known kind")
+	}
+	if IsKnownKind("MyCustomResource") {
+		t.Error("MyCustomResource should not be a known kind")
+	}
+}
+
+func TestSortOrdersByTierStably(t *testing.T) {
+	in := []Resource{
+		{Kind: "Deployment", Meta: api.ObjectMeta{Name: "web"}},
+		{Kind: "Namespace", Meta: api.ObjectMeta{Name: "ns"}},
+		{Kind: "ConfigMap", Meta: api.ObjectMeta{Name: "cm"}},
+		{Kind: "MyCustomResource", Meta: api.ObjectMeta{Name: "cr"}},
+		{Kind: "Service", Meta: api.ObjectMeta{Name: "svc"}},
+	}
+	got := Sort(in)
+
+	wantOrder := []string{"ns", "cm", "svc", "web", "cr"}
+	if len(got) != len(wantOrder) {
+		t.Fatalf("Sort returned %d resources, want %d", len(got), len(wantOrder))
+	}
+	for i, name := range wantOrder {
+		if got[i].Meta.Name != name {
+			t.Errorf("Sort()[%d].Meta.Name = %q, want %q (full order: %v)", i, got[i].Meta.Name, name, namesOf(got))
+		}
+	}
+}
+
+func namesOf(resources []Resource) []string {
+	names := make([]string, len(resources))
+	for i, r := range resources {
+		names[i] = r.Meta.Name
+	}
+	return names
+}
@@ -0,0 +1,51 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "A named cluster configuration. Resources select one via their `cluster` attribute; the first block is the default for resources that don't set one.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":                   {Type: schema.TypeString, Required: true},
+						"config_path":            {Type: schema.TypeString, Optional: true},
+						"config_context":         {Type: schema.TypeString, Optional: true},
+						"host":                   {Type: schema.TypeString, Optional: true},
+						"token":                  {Type: schema.TypeString, Optional: true, Sensitive: true},
+						"client_certificate":     {Type: schema.TypeString, Optional: true},
+						"client_key":             {Type: schema.TypeString, Optional: true, Sensitive: true},
+						"cluster_ca_certificate": {Type: schema.TypeString, Optional: true},
+						"insecure":               {Type: schema.TypeBool, Optional: true, Default: false},
+					},
+				},
+			},
+			"wait_for_dependencies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Block Create/Update on a kind's prerequisites (e.g. a CR's CRD) being ready before applying.",
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"kubernetes_manifest": resourceKubernetesManifest(),
+			"kubernetes_secret":   resourceKubernetesSecret(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"kubernetes_live_diff": dataSourceKubernetesLiveDiff(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	configs := expandClusterConfigs(d.Get("cluster").([]interface{}))
+	return newKubeClientsets(configs, d.Get("wait_for_dependencies").(bool))
+}
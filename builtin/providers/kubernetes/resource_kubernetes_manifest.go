@@ -0,0 +1,412 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform/builtin/providers/kubernetes/fieldpath"
+	"github.com/hashicorp/terraform/builtin/providers/kubernetes/ordering"
+	"github.com/hashicorp/terraform/helper/schema"
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+// resourceKubernetesManifest returns a generic resource that applies
+// arbitrary YAML/JSON to the cluster via server-side apply, for Kinds (in
+// particular CRDs) that don't have a strongly-typed `kubernetes_*` resource
+// of their own. Before applying a single object, it checks that the fields
+// the cluster's OpenAPI schema marks as required for the Kind are present;
+// the schema is not used to compute diffs or PATCH bodies, and the items of
+// a `kind = "List"` manifest are not checked against it.
+//
+// `kind = "List"` is also accepted: `manifest.items` is then a bundle of
+// several objects (e.g. a CRD plus the CRs that depend on it) applied
+// together in ordering.Sort's dependency order, in one Terraform resource.
+func resourceKubernetesManifest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesManifestCreate,
+		Read:   resourceKubernetesManifestRead,
+		Update: resourceKubernetesManifestUpdate,
+		Delete: resourceKubernetesManifestDelete,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the cluster configuration (see the provider's `cluster` blocks) this manifest is applied to.",
+			},
+			"api_version": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"kind": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"metadata": metadataSchema(),
+			"manifest": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Description: "The object's spec and any other fields beyond apiVersion/kind/metadata, as decoded YAML/JSON. For `kind = \"List\"`, this holds a single `items` key: a list of full objects (apiVersion/kind/metadata/spec each), applied together in dependency order.",
+			},
+			"field_manager": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "terraform-kubernetes-provider",
+			},
+			"force_conflicts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Force ownership of fields currently managed by a different field manager.",
+			},
+		},
+	}
+}
+
+// manifestDocument builds a fieldpath.Document over a kubernetes_manifest
+// resource's current apiVersion/kind/metadata/manifest fields.
+func manifestDocument(d *schema.ResourceData) fieldpath.Document {
+	root := map[string]interface{}{
+		"apiVersion": d.Get("api_version"),
+		"kind":       d.Get("kind"),
+		"metadata":   flattenMetadata(expandMetadata(d.Get("metadata").([]interface{})))[0],
+	}
+	for k, v := range d.Get("manifest").(map[string]interface{}) {
+		root[k] = v
+	}
+	return fieldpath.NewDocument(root)
+}
+
+// validateAgainstOpenAPISchema checks that every field the OpenAPI schema
+// marks as required for this group/version/kind is present in doc,
+// refreshing the schema cache once if the Kind isn't known yet (its CRD may
+// have just been applied).
+func validateAgainstOpenAPISchema(cache *openAPISchemaCache, group, version, kind string, doc fieldpath.Document) error {
+	def, err := cache.definitionForGVK(group, version, kind, true)
+	if err != nil {
+		return err
+	}
+	for _, required := range def.Required {
+		if _, err := doc.GetString(required); err != nil {
+			if _, sliceErr := doc.GetSlice(required); sliceErr != nil {
+				if _, mapErr := doc.GetStringMap(required); mapErr != nil {
+					return fmt.Errorf("kubernetes_manifest: missing required field %q", required)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func resourceKubernetesManifestCreate(d *schema.ResourceData, meta interface{}) error {
+	return applyKubernetesManifest(d, meta)
+}
+
+func resourceKubernetesManifestUpdate(d *schema.ResourceData, meta interface{}) error {
+	return applyKubernetesManifest(d, meta)
+}
+
+func resourceKubernetesManifestRead(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+	d.SetId(migrateIDToClusterForm(d.Id(), clients.defaultCluster))
+
+	if d.Get("kind").(string) == "List" {
+		return readManifestList(d, clients)
+	}
+
+	cluster, namespace, name := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	obj, err := getManifestObject(client, d.Get("api_version").(string), d.Get("kind").(string), namespace, name)
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("kubernetes_manifest: reading %s/%s: %s", namespace, name, err)
+	}
+
+	return flattenManifestObject(d, obj)
+}
+
+func resourceKubernetesManifestDelete(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("kind").(string) == "List" {
+		return deleteManifestList(d, clients)
+	}
+
+	cluster, namespace, name := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	path := resourcePath(d.Get("api_version").(string), d.Get("kind").(string), namespace, name)
+	result := client.Delete().AbsPath(path).Do()
+	if err := result.Error(); err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("kubernetes_manifest: deleting %s/%s: %s", namespace, name, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyKubernetesManifest issues the field-manager-aware server-side apply
+// PATCH(es) for the resource's current apiVersion/kind/metadata/manifest,
+// after validating against the target cluster's OpenAPI schema and waiting
+// on dependencies (if `wait_for_dependencies` is enabled). `kind = "List"`
+// applies every item in ordering.Sort's order instead of a single object.
+func applyKubernetesManifest(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("kind").(string) == "List" {
+		return applyManifestList(d, meta, clients)
+	}
+
+	kind := d.Get("kind").(string)
+	apiVersion := d.Get("api_version").(string)
+	cluster := resourceCluster(d)
+
+	if err := waitForDependencies(clients.waitForDependencies, cluster, kind, meta); err != nil {
+		return err
+	}
+
+	host, err := clients.hostForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	cache := newOpenAPISchemaCache(host, http.DefaultClient)
+	doc := manifestDocument(d)
+	group, version := parseAPIVersion(apiVersion)
+	if err := validateAgainstOpenAPISchema(cache, group, version, kind, doc); err != nil {
+		return err
+	}
+
+	objectMeta := expandMetadata(d.Get("metadata").([]interface{}))
+	body := manifestRoot(apiVersion, kind, objectMeta, d.Get("manifest").(map[string]interface{}))
+	if err := applyManifestItem(clients, cluster, apiVersion, kind, objectMeta, body, d.Get("field_manager").(string), d.Get("force_conflicts").(bool)); err != nil {
+		return err
+	}
+
+	d.SetId(buildId(cluster, objectMeta))
+	return resourceKubernetesManifestRead(d, meta)
+}
+
+// applyManifestItem issues a single server-side apply PATCH for one fully
+// assembled object body. It's the unit of work ordering.Sort sequences for
+// `kind = "List"`, and what the single-object path above uses directly.
+func applyManifestItem(clients *kubeClientsets, cluster, apiVersion, kind string, objectMeta api.ObjectMeta, body map[string]interface{}, fieldManager string, forceConflicts bool) error {
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("kubernetes_manifest: encoding %s %s/%s: %s", kind, objectMeta.Namespace, objectMeta.Name, err)
+	}
+
+	path := resourcePath(apiVersion, kind, objectMeta.Namespace, objectMeta.Name)
+	result := client.Patch("application/apply-patch+yaml").
+		AbsPath(path).
+		Param("fieldManager", fieldManager).
+		Param("force", fmt.Sprintf("%t", forceConflicts)).
+		Body(encoded).
+		Do()
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("kubernetes_manifest: applying %s %s/%s: %s", kind, objectMeta.Namespace, objectMeta.Name, err)
+	}
+	return nil
+}
+
+// manifestListItems decodes `manifest.items` into full object bodies.
+func manifestListItems(manifest map[string]interface{}) ([]map[string]interface{}, error) {
+	raw, _ := manifest["items"].([]interface{})
+	items := make([]map[string]interface{}, 0, len(raw))
+	for _, v := range raw {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("kubernetes_manifest: list item is a %T, not an object", v)
+		}
+		items = append(items, obj)
+	}
+	return items, nil
+}
+
+// sortManifestItems orders item bodies into apply order via ordering.Sort,
+// matching each sorted ordering.Resource back to its full body by kind and
+// name/namespace (ordering.Resource itself carries no payload).
+func sortManifestItems(items []map[string]interface{}) []map[string]interface{} {
+	resources := make([]ordering.Resource, len(items))
+	for i, obj := range items {
+		kind, _ := obj["kind"].(string)
+		metaRaw, _ := obj["metadata"].(map[string]interface{})
+		resources[i] = ordering.Resource{Kind: kind, Meta: expandMetadataFromWire(metaRaw)}
+	}
+
+	sorted := make([]map[string]interface{}, 0, len(items))
+	for _, r := range ordering.Sort(resources) {
+		for _, obj := range items {
+			kind, _ := obj["kind"].(string)
+			metaRaw, _ := obj["metadata"].(map[string]interface{})
+			m := expandMetadataFromWire(metaRaw)
+			if kind == r.Kind && m.Name == r.Meta.Name && m.Namespace == r.Meta.Namespace {
+				sorted = append(sorted, obj)
+				break
+			}
+		}
+	}
+	return sorted
+}
+
+// applyManifestList applies every item of a `kind = "List"` manifest in
+// ordering.Sort's dependency order, so a CRD bundled alongside its CRs is
+// always applied before them.
+func applyManifestList(d *schema.ResourceData, meta interface{}, clients *kubeClientsets) error {
+	items, err := manifestListItems(d.Get("manifest").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	cluster := resourceCluster(d)
+	for _, obj := range sortManifestItems(items) {
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		metaRaw, _ := obj["metadata"].(map[string]interface{})
+		objectMeta := expandMetadataFromWire(metaRaw)
+
+		if err := waitForDependencies(clients.waitForDependencies, cluster, kind, meta); err != nil {
+			return err
+		}
+		if err := applyManifestItem(clients, cluster, apiVersion, kind, objectMeta, obj, d.Get("field_manager").(string), d.Get("force_conflicts").(bool)); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(buildId(cluster, expandMetadata(d.Get("metadata").([]interface{}))))
+	return resourceKubernetesManifestRead(d, meta)
+}
+
+// readManifestList refreshes every item of a `kind = "List"` manifest,
+// treating the whole resource as gone if any one item is missing.
+func readManifestList(d *schema.ResourceData, clients *kubeClientsets) error {
+	items, err := manifestListItems(d.Get("manifest").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	cluster, _, _ := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	refreshed := make([]interface{}, 0, len(items))
+	for _, obj := range items {
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		metaRaw, _ := obj["metadata"].(map[string]interface{})
+		objectMeta := expandMetadataFromWire(metaRaw)
+
+		live, err := getManifestObject(client, apiVersion, kind, objectMeta.Namespace, objectMeta.Name)
+		if err != nil {
+			if isNotFoundError(err) {
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("kubernetes_manifest: reading list item %s %s/%s: %s", kind, objectMeta.Namespace, objectMeta.Name, err)
+		}
+		refreshed = append(refreshed, live)
+	}
+
+	return d.Set("manifest", map[string]interface{}{"items": refreshed})
+}
+
+// deleteManifestList deletes every item of a `kind = "List"` manifest in
+// reverse apply order, undoing dependencies last-applied-first.
+func deleteManifestList(d *schema.ResourceData, clients *kubeClientsets) error {
+	items, err := manifestListItems(d.Get("manifest").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	cluster, _, _ := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	sorted := sortManifestItems(items)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		obj := sorted[i]
+		apiVersion, _ := obj["apiVersion"].(string)
+		kind, _ := obj["kind"].(string)
+		metaRaw, _ := obj["metadata"].(map[string]interface{})
+		objectMeta := expandMetadataFromWire(metaRaw)
+
+		path := resourcePath(apiVersion, kind, objectMeta.Namespace, objectMeta.Name)
+		result := client.Delete().AbsPath(path).Do()
+		if err := result.Error(); err != nil && !isNotFoundError(err) {
+			return fmt.Errorf("kubernetes_manifest: deleting list item %s %s/%s: %s", kind, objectMeta.Namespace, objectMeta.Name, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// manifestRoot assembles the full object body sent to the API server:
+// apiVersion/kind/metadata plus the user-supplied manifest fields.
+func manifestRoot(apiVersion, kind string, objectMeta api.ObjectMeta, manifest map[string]interface{}) map[string]interface{} {
+	root := map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   wireMetadata(objectMeta),
+	}
+	for k, v := range manifest {
+		root[k] = v
+	}
+	return root
+}
+
+// wireMetadata builds the metadata object sent on the wire: just the fields
+// a client is allowed to set, unlike flattenMetadata's schema-shaped output
+// which also carries server-populated fields like resource_version.
+func wireMetadata(meta api.ObjectMeta) map[string]interface{} {
+	m := map[string]interface{}{"name": meta.Name}
+	if meta.Namespace != "" {
+		m["namespace"] = meta.Namespace
+	}
+	if len(meta.Labels) > 0 {
+		m["labels"] = meta.Labels
+	}
+	if len(meta.Annotations) > 0 {
+		m["annotations"] = meta.Annotations
+	}
+	return m
+}
+
+func manifestClients(meta interface{}) (*kubeClientsets, error) {
+	clients, ok := meta.(*kubeClientsets)
+	if !ok {
+		return nil, fmt.Errorf("kubernetes_manifest: provider meta is not configured for multi-cluster clients")
+	}
+	return clients, nil
+}
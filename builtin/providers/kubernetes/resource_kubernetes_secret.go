@@ -0,0 +1,294 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceKubernetesSecret manages a core/v1 Secret. Values under
+// `sensitive_data` are stored in state as ciphertext and only ever
+// decrypted in memory for the API call; `encryption` says how.
+func resourceKubernetesSecret() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesSecretCreate,
+		Read:   resourceKubernetesSecretRead,
+		Update: resourceKubernetesSecretUpdate,
+		Delete: resourceKubernetesSecretDelete,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the cluster configuration (see the provider's `cluster` blocks) this secret is applied to.",
+			},
+			"metadata": metadataSchema(),
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Opaque",
+				ForceNew: true,
+			},
+			"data": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"sensitive_data": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Encrypted values (see `encryption`). Decrypted only in memory before being sent to the API.",
+			},
+			"encryption": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"provider": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"recipients": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"identity_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to the age identity (private key) file. Required when `provider` is \"age\".",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// secretDecrypterFromResourceData builds the SecretDecrypter configured by a
+// kubernetes_secret resource's `encryption` block, or nil if none is set.
+func secretDecrypterFromResourceData(d *schema.ResourceData) (SecretDecrypter, error) {
+	blocks := d.Get("encryption").([]interface{})
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	block := blocks[0].(map[string]interface{})
+	return secretDecrypterForProvider(
+		block["provider"].(string),
+		sliceOfString(block["recipients"].([]interface{})),
+		block["identity_path"].(string),
+	)
+}
+
+// secretWireData merges `data` (sent as plaintext, base64-encoded for the
+// wire) with the decrypted contents of `sensitive_data`.
+func secretWireData(d *schema.ResourceData) (map[string]interface{}, error) {
+	wire := base64EncodeStringMap(d.Get("data").(map[string]interface{}))
+
+	sensitive := d.Get("sensitive_data").(map[string]interface{})
+	if len(sensitive) == 0 {
+		return wire, nil
+	}
+
+	decrypter, err := secretDecrypterFromResourceData(d)
+	if err != nil {
+		return nil, err
+	}
+	if decrypter == nil {
+		return nil, fmt.Errorf("kubernetes_secret: sensitive_data is set but no encryption block is configured")
+	}
+
+	plaintext, err := decryptSensitiveStringMap(decrypter, sensitive)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range base64EncodeStringMap(stringMapToInterfaceMap(plaintext)) {
+		wire[k] = v
+	}
+	return wire, nil
+}
+
+func stringMapToInterfaceMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+func resourceKubernetesSecretCreate(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+	objectMeta := expandMetadata(d.Get("metadata").([]interface{}))
+
+	data, err := secretWireData(d)
+	if err != nil {
+		return err
+	}
+
+	cluster := resourceCluster(d)
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   wireMetadata(objectMeta),
+		"type":       d.Get("type").(string),
+		"data":       data,
+	})
+	if err != nil {
+		return fmt.Errorf("kubernetes_secret: encoding secret: %s", err)
+	}
+
+	path := resourcePath("v1", "Secret", objectMeta.Namespace, "")
+	result := client.Post().AbsPath(path).Body(body).Do()
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("kubernetes_secret: creating %s/%s: %s", objectMeta.Namespace, objectMeta.Name, err)
+	}
+
+	d.SetId(buildId(cluster, objectMeta))
+	return resourceKubernetesSecretRead(d, meta)
+}
+
+func resourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+	d.SetId(migrateIDToClusterForm(d.Id(), clients.defaultCluster))
+
+	cluster, namespace, name := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	obj, err := getRawObject(client, resourcePath("v1", "Secret", namespace, name))
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("kubernetes_secret: reading %s/%s: %s", namespace, name, err)
+	}
+
+	metaRaw, _ := obj["metadata"].(map[string]interface{})
+	if err := d.Set("metadata", flattenMetadata(expandMetadataFromWire(metaRaw))); err != nil {
+		return err
+	}
+	if t, ok := obj["type"].(string); ok {
+		d.Set("type", t)
+	}
+
+	plaintext, err := decodeBase64DataMap(obj["data"])
+	if err != nil {
+		return fmt.Errorf("kubernetes_secret: decoding %s/%s data: %s", namespace, name, err)
+	}
+
+	// sensitive_data keys are intentionally left untouched here: age/gpg
+	// produce different ciphertext on every Encrypt call even for identical
+	// plaintext, so re-encrypting on every Read would make the ciphertext
+	// drift from the config's own literal on every refresh. The config's
+	// ciphertext is the source of truth; Update decrypts it for the API call
+	// but never rewrites it.
+	sensitiveKeys := d.Get("sensitive_data").(map[string]interface{})
+	data := make(map[string]interface{}, len(plaintext))
+	for k, v := range plaintext {
+		if _, ok := sensitiveKeys[k]; ok {
+			continue
+		}
+		data[k] = v
+	}
+	return d.Set("data", data)
+}
+
+func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+	objectMeta := expandMetadata(d.Get("metadata").([]interface{}))
+
+	data, err := secretWireData(d)
+	if err != nil {
+		return err
+	}
+
+	cluster, namespace, name := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   wireMetadata(objectMeta),
+		"type":       d.Get("type").(string),
+		"data":       data,
+	})
+	if err != nil {
+		return fmt.Errorf("kubernetes_secret: encoding secret: %s", err)
+	}
+
+	path := resourcePath("v1", "Secret", namespace, name)
+	result := client.Put().AbsPath(path).Body(body).Do()
+	if err := result.Error(); err != nil {
+		return fmt.Errorf("kubernetes_secret: updating %s/%s: %s", namespace, name, err)
+	}
+
+	return resourceKubernetesSecretRead(d, meta)
+}
+
+func resourceKubernetesSecretDelete(d *schema.ResourceData, meta interface{}) error {
+	clients, err := manifestClients(meta)
+	if err != nil {
+		return err
+	}
+	cluster, namespace, name := idParts(d.Id())
+	client, err := clients.clientForCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	path := resourcePath("v1", "Secret", namespace, name)
+	result := client.Delete().AbsPath(path).Do()
+	if err := result.Error(); err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("kubernetes_secret: deleting %s/%s: %s", namespace, name, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// decodeBase64DataMap base64-decodes a Secret's wire-format "data" map
+// (string keys to base64 string values) into plaintext strings.
+func decodeBase64DataMap(v interface{}) (map[string]string, error) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return map[string]string{}, nil
+	}
+	result := make(map[string]string, len(m))
+	for k, raw := range m {
+		s, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %s", k, err)
+		}
+		result[k] = string(decoded)
+	}
+	return result, nil
+}
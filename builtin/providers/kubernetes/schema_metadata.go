@@ -0,0 +1,59 @@
+package kubernetes
+
+import "github.com/hashicorp/terraform/helper/schema"
+
+// metadataSchema returns the standard `metadata` block shared by every
+// resource, matching the fields expandMetadata/flattenMetadata read and write.
+func metadataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"annotations": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"labels": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"generate_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+					ForceNew: true,
+				},
+				"namespace": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"resource_version": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"self_link": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"uid": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"generation": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,160 @@
+package kubernetes
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SecretDecrypter decrypts ciphertext from a `kubernetes_secret` resource's
+// `sensitive_data` map before it is sent to the API. Encrypt produces
+// ciphertext for the configured recipients; resourceKubernetesSecretRead does
+// not call it, and does not re-encrypt values read back from the API.
+type SecretDecrypter interface {
+	Decrypt(ciphertext string) (string, error)
+	Encrypt(plaintext string) (string, error)
+}
+
+// secretDecrypterForProvider returns the SecretDecrypter for an
+// `encryption { provider = ... }` block. For "kms" the concrete
+// implementation (AWS or GCP) is chosen from the recipient key identifier's
+// format: an AWS key ARN or a GCP "projects/.../cryptoKeys/..." resource name.
+func secretDecrypterForProvider(provider string, recipients []string, identityPath string) (SecretDecrypter, error) {
+	switch provider {
+	case "age":
+		return newAgeSecretDecrypter(recipients, identityPath)
+	case "gpg":
+		return newGPGSecretDecrypter(recipients)
+	case "kms":
+		return newKMSSecretDecrypter(recipients)
+	default:
+		return nil, fmt.Errorf("kubernetes_secret: unknown encryption provider %q", provider)
+	}
+}
+
+// runPipe runs name with args, writing input to its stdin and returning its
+// stdout. It's the shared plumbing for the age/gpg/cloud-CLI decrypters
+// below, all of which work as "pipe in, pipe out" filters.
+func runPipe(input string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %s: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// ageSecretDecrypter shells out to the `age` CLI. Decrypt needs an identity
+// (private key) file; Encrypt needs the recipients' public keys.
+type ageSecretDecrypter struct {
+	recipients   []string
+	identityPath string
+}
+
+func newAgeSecretDecrypter(recipients []string, identityPath string) (*ageSecretDecrypter, error) {
+	if identityPath == "" {
+		return nil, fmt.Errorf("kubernetes_secret: age encryption requires `identity_path`")
+	}
+	return &ageSecretDecrypter{recipients: recipients, identityPath: identityPath}, nil
+}
+
+func (d *ageSecretDecrypter) Decrypt(ciphertext string) (string, error) {
+	return runPipe(ciphertext, "age", "--decrypt", "--identity", d.identityPath)
+}
+
+func (d *ageSecretDecrypter) Encrypt(plaintext string) (string, error) {
+	args := []string{"--encrypt"}
+	for _, r := range d.recipients {
+		args = append(args, "-r", r)
+	}
+	return runPipe(plaintext, "age", args...)
+}
+
+// gpgSecretDecrypter shells out to the `gpg` CLI, using whatever secret keys
+// are already available to the local gpg-agent to decrypt, and the
+// configured recipients' public keys to encrypt.
+type gpgSecretDecrypter struct {
+	recipients []string
+}
+
+func newGPGSecretDecrypter(recipients []string) (*gpgSecretDecrypter, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("kubernetes_secret: gpg encryption requires at least one recipient")
+	}
+	return &gpgSecretDecrypter{recipients: recipients}, nil
+}
+
+func (d *gpgSecretDecrypter) Decrypt(ciphertext string) (string, error) {
+	return runPipe(ciphertext, "gpg", "--batch", "--yes", "--decrypt")
+}
+
+func (d *gpgSecretDecrypter) Encrypt(plaintext string) (string, error) {
+	args := []string{"--batch", "--yes", "--encrypt", "--armor"}
+	for _, r := range d.recipients {
+		args = append(args, "-r", r)
+	}
+	return runPipe(plaintext, "gpg", args...)
+}
+
+// newKMSSecretDecrypter picks the AWS or GCP KMS implementation based on the
+// shape of the first recipient key identifier.
+func newKMSSecretDecrypter(recipients []string) (SecretDecrypter, error) {
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("kubernetes_secret: kms encryption requires at least one key recipient")
+	}
+	key := recipients[0]
+	switch {
+	case strings.HasPrefix(key, "arn:aws:kms:"):
+		return &awsKMSSecretDecrypter{keyID: key}, nil
+	case strings.HasPrefix(key, "projects/"):
+		return &gcpKMSSecretDecrypter{keyResource: key}, nil
+	default:
+		return nil, fmt.Errorf("kubernetes_secret: could not determine KMS provider from key %q (expected an AWS key ARN or a GCP cryptoKeys resource name)", key)
+	}
+}
+
+// awsKMSSecretDecrypter shells out to the `aws` CLI's `kms` subcommands.
+// Ciphertext/plaintext are base64 blobs, matching what `aws kms` reads/writes.
+type awsKMSSecretDecrypter struct {
+	keyID string
+}
+
+func (d *awsKMSSecretDecrypter) Decrypt(ciphertext string) (string, error) {
+	return runPipe(ciphertext, "aws", "kms", "decrypt", "--ciphertext-blob", "fileb:///dev/stdin", "--output", "text", "--query", "Plaintext")
+}
+
+func (d *awsKMSSecretDecrypter) Encrypt(plaintext string) (string, error) {
+	return runPipe(plaintext, "aws", "kms", "encrypt", "--key-id", d.keyID, "--plaintext", "fileb:///dev/stdin", "--output", "text", "--query", "CiphertextBlob")
+}
+
+// gcpKMSSecretDecrypter shells out to `gcloud kms`.
+type gcpKMSSecretDecrypter struct {
+	keyResource string
+}
+
+func (d *gcpKMSSecretDecrypter) Decrypt(ciphertext string) (string, error) {
+	return runPipe(ciphertext, "gcloud", "kms", "decrypt", "--key", d.keyResource, "--ciphertext-file", "-", "--plaintext-file", "-")
+}
+
+func (d *gcpKMSSecretDecrypter) Encrypt(plaintext string) (string, error) {
+	return runPipe(plaintext, "gcloud", "kms", "encrypt", "--key", d.keyResource, "--plaintext-file", "-", "--ciphertext-file", "-")
+}
+
+// decryptSensitiveStringMap decrypts every value of a `sensitive_data` map,
+// for merging into the payload sent to the API alongside
+// base64EncodeStringMap(d.Get("data")).
+func decryptSensitiveStringMap(decrypter SecretDecrypter, m map[string]interface{}) (map[string]string, error) {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		plaintext, err := decrypter.Decrypt(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes_secret: decrypting %q: %s", k, err)
+		}
+		result[k] = plaintext
+	}
+	return result, nil
+}
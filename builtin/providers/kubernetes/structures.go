@@ -7,18 +7,45 @@ import (
 	"strings"
 
 	"encoding/base64"
+	"github.com/hashicorp/terraform/builtin/providers/kubernetes/fieldpath"
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/kubernetes/pkg/api/resource"
 	api "k8s.io/kubernetes/pkg/api/v1"
 )
 
-func idParts(id string) (string, string) {
+// idParts splits an ID into cluster, namespace and name. Pre-multi-cluster
+// two-segment IDs come back with an empty cluster.
+func idParts(id string) (string, string, string) {
 	parts := strings.Split(id, "/")
-	return parts[0], parts[1]
+	if len(parts) == 2 {
+		return "", parts[0], parts[1]
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+// buildId builds the cluster/namespace/name ID; cluster is omitted when empty.
+func buildId(cluster string, meta api.ObjectMeta) string {
+	if cluster == "" {
+		return meta.Namespace + "/" + meta.Name
+	}
+	return cluster + "/" + meta.Namespace + "/" + meta.Name
 }
 
-func buildId(meta api.ObjectMeta) string {
-	return meta.Namespace + "/" + meta.Name
+// resourceCluster returns the resource's `cluster` attribute, or "" for the default cluster.
+func resourceCluster(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("cluster"); ok {
+		return v.(string)
+	}
+	return ""
+}
+
+// migrateIDToClusterForm upgrades a pre-multi-cluster "namespace/name" ID to
+// "cluster/namespace/name". Each resource's Read calls it on the stored ID.
+func migrateIDToClusterForm(id, defaultCluster string) string {
+	if strings.Count(id, "/") >= 2 {
+		return id
+	}
+	return defaultCluster + "/" + id
 }
 
 func expandMetadata(in []interface{}) api.ObjectMeta {
@@ -44,19 +71,45 @@ func expandMetadata(in []interface{}) api.ObjectMeta {
 	return meta
 }
 
+// patchMetadata builds the PATCH operations for a resource's annotations and
+// labels. It delegates the actual diffing/escaping to fieldpath.PatchBuilder
+// so that every resource gets the same add-vs-replace semantics and correct
+// handling of "~"/"/" in annotation and label keys.
 func patchMetadata(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
-	ops := make([]PatchOperation, 0, 0)
+	builder := fieldpath.NewPatchBuilder()
 	if d.HasChange(keyPrefix + "annotations") {
 		oldV, newV := d.GetChange(keyPrefix + "annotations")
-		diffOps := diffStringMap(pathPrefix+"annotations", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-		ops = append(ops, diffOps...)
+		segments := mapPathSegments(pathPrefix, "annotations")
+		builder.AddOrReplaceStringMap(expandStringMap(oldV.(map[string]interface{})), expandStringMap(newV.(map[string]interface{})), segments...)
 	}
 	if d.HasChange(keyPrefix + "labels") {
 		oldV, newV := d.GetChange(keyPrefix + "labels")
-		diffOps := diffStringMap(pathPrefix+"labels", oldV.(map[string]interface{}), newV.(map[string]interface{}))
-		ops = append(ops, diffOps...)
+		segments := mapPathSegments(pathPrefix, "labels")
+		builder.AddOrReplaceStringMap(expandStringMap(oldV.(map[string]interface{})), expandStringMap(newV.(map[string]interface{})), segments...)
 	}
-	return ops
+	return fieldPathOperationsToPatchOperations(builder.Operations())
+}
+
+// mapPathSegments turns the legacy "/metadata/" + "annotations"-style path
+// prefix/field pair used by this resource's schema into the segment slice
+// PatchBuilder expects.
+func mapPathSegments(pathPrefix, field string) []string {
+	segments := strings.Split(strings.Trim(pathPrefix, "/"), "/")
+	if pathPrefix == "" {
+		segments = nil
+	}
+	return append(segments, field)
+}
+
+// fieldPathOperationsToPatchOperations adapts fieldpath.Operation values to
+// this package's pre-existing PatchOperation type, so callers written
+// against patchMetadata don't need to change.
+func fieldPathOperationsToPatchOperations(ops []fieldpath.Operation) PatchOperations {
+	out := make(PatchOperations, 0, len(ops))
+	for _, op := range ops {
+		out = append(out, &PatchOperation{Op: op.Op, Path: op.Path, Value: op.Value})
+	}
+	return out
 }
 
 func expandStringMap(m map[string]interface{}) map[string]string {